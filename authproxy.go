@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// needsAuthProxy reports whether server has credentials that a subprocess
+// `ollama` binary can't express on its own (it only understands
+// OLLAMA_HOST), meaning requests need to be routed through a local proxy
+// that injects them.
+func needsAuthProxy(s *OllamaServer) bool {
+	return s.BearerToken != "" || s.BasicAuthUser != "" || len(s.Headers) > 0
+}
+
+// startAuthProxy launches a local reverse proxy on 127.0.0.1:0 that
+// forwards every request to server, injecting its configured auth headers
+// along the way. It returns the proxy's listen address (suitable for
+// OLLAMA_HOST) and a func to shut the proxy down.
+func startAuthProxy(server *OllamaServer) (string, func(), error) {
+	target, err := url.Parse(serverBaseURL(server))
+	if err != nil {
+		return "", nil, err
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	// Reuse buildHTTPClient's transport so the proxy honors the same
+	// CACertPath/InsecureSkipVerify settings as the native client path,
+	// not just auth headers.
+	proxy.Transport = buildHTTPClient(server).Transport
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+
+	srv := &http.Server{Handler: proxy}
+	go srv.Serve(listener)
+
+	stop := func() { srv.Close() }
+	return listener.Addr().String(), stop, nil
+}
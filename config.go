@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Configuration types
+type OllamaServer struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Group   string `json:"group,omitempty"`
+	Weight  int    `json:"weight,omitempty"`
+
+	// Connection options. Scheme defaults to "http" when empty.
+	Scheme             string            `json:"scheme,omitempty"`
+	BearerToken        string            `json:"bearerToken,omitempty"`
+	BasicAuthUser      string            `json:"basicAuthUser,omitempty"`
+	BasicAuthPass      string            `json:"basicAuthPass,omitempty"`
+	CACertPath         string            `json:"caCertPath,omitempty"`
+	InsecureSkipVerify bool              `json:"insecureSkipVerify,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	TimeoutSeconds     int               `json:"timeoutSeconds,omitempty"`
+}
+
+type Config struct {
+	Servers    []OllamaServer `json:"servers"`
+	Current    string         `json:"current"`
+	ConfigPath string         `json:"-"`
+}
+
+// Global variables
+var config Config
+var version = "0.1.0"
+var appName = "ollamanager"
+var description = "A wrapper for ollama that allows controlling ollama instances on your internal network"
+
+// Configuration management functions
+func initConfig() {
+	configPath := configPathFlag
+	if configPath == "" {
+		// Get user config directory
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			fmt.Printf("Warning: Could not determine config directory: %v\n", err)
+			configDir = "."
+		}
+
+		// Create config directory
+		ollamaDir := filepath.Join(configDir, "ollamanager")
+		if err := os.MkdirAll(ollamaDir, 0755); err != nil {
+			fmt.Printf("Error creating config directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		configPath = filepath.Join(ollamaDir, "config.json")
+	}
+	config.ConfigPath = configPath
+
+	// Create default config if it doesn't exist
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		config = Config{
+			Servers: []OllamaServer{
+				{Name: "default", Address: "127.0.0.1:11434"},
+			},
+			Current:    "default",
+			ConfigPath: configPath,
+		}
+		if err := saveConfig(); err != nil {
+			fmt.Printf("Error creating default config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Created default config at %s\n", configPath)
+		return
+	}
+
+	// Read existing config
+	file, err := os.Open(configPath)
+	if err != nil {
+		fmt.Printf("Error opening config file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&config); err != nil {
+		// Backup corrupted config
+		backupPath := configPath + ".bak." + time.Now().Format("20060102150405")
+		if backupErr := os.Rename(configPath, backupPath); backupErr == nil {
+			fmt.Printf("Backed up corrupted config to: %s\n", backupPath)
+		}
+
+		// Create new default config
+		config = Config{
+			Servers: []OllamaServer{
+				{Name: "default", Address: "127.0.0.1:11434"},
+			},
+			Current:    "default",
+			ConfigPath: configPath,
+		}
+		if saveErr := saveConfig(); saveErr != nil {
+			fmt.Printf("Error creating new config: %v\n", saveErr)
+			os.Exit(1)
+		}
+		fmt.Printf("Created new default config due to corruption\n")
+	}
+
+	// Ensure config has a valid current server
+	if config.Current == "" || !serverExists(config.Current) {
+		config.Current = "default"
+		saveConfig()
+	}
+}
+
+func saveConfig() error {
+	file, err := os.Create(config.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("error creating config file: %v", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config); err != nil {
+		return fmt.Errorf("error encoding config file: %v", err)
+	}
+	return nil
+}
+
+// Helper functions
+func serverExists(name string) bool {
+	for _, server := range config.Servers {
+		if server.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func getCurrentServer() *OllamaServer {
+	for i, server := range config.Servers {
+		if server.Name == config.Current {
+			return &config.Servers[i]
+		}
+	}
+	return nil
+}
+
+func serverByName(name string) *OllamaServer {
+	for i, server := range config.Servers {
+		if server.Name == name {
+			return &config.Servers[i]
+		}
+	}
+	return nil
+}
+
+func getCurrentServerName() string {
+	server := getCurrentServer()
+	if server == nil {
+		return "none"
+	}
+	return server.Name
+}
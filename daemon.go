@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"ollamanager/farm"
+)
+
+// runDaemon runs ollamanager as a long-lived HTTP server speaking an
+// Ollama-compatible API, dispatching each request to whichever registered
+// backend can actually serve it.
+func runDaemon(listen string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/tags", handleDaemonTags)
+	mux.HandleFunc("/api/version", handleDaemonVersion)
+	mux.HandleFunc("/api/show", handleDaemonProxy("POST"))
+	mux.HandleFunc("/api/pull", handleDaemonProxy("POST"))
+	mux.HandleFunc("/api/generate", handleDaemonGenerateOrChat)
+	mux.HandleFunc("/api/chat", handleDaemonGenerateOrChat)
+	mux.HandleFunc("/version", handleDaemonOwnVersion)
+	mux.HandleFunc("/models", handleDaemonModels)
+
+	fmt.Printf("ollamanager daemon listening on %s\n", listen)
+	if err := http.ListenAndServe(listen, mux); err != nil {
+		fmt.Printf("Error running daemon: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// modelRequest is the subset of fields every Ollama API request carries
+// that names the model to dispatch on. Most endpoints (generate, chat,
+// pull) use "model"; /api/show uses "name" instead.
+type modelRequest struct {
+	Model string `json:"model"`
+	Name  string `json:"name"`
+}
+
+func (r modelRequest) modelName() string {
+	if r.Model != "" {
+		return r.Model
+	}
+	return r.Name
+}
+
+// backendFor resolves the config.Servers entry backing a server the farm
+// selected, so the request can be forwarded with its auth/TLS settings
+// honored. Farm state can outlive a server being removed from config; in
+// that case we fall back to what the farm told us, same as
+// runOllamaCommandAny.
+func backendFor(selected *farm.Server) *OllamaServer {
+	if server := serverByName(selected.Name); server != nil {
+		return server
+	}
+	return &OllamaServer{Name: selected.Name, Address: selected.Address, Scheme: selected.Scheme}
+}
+
+// postToBackend POSTs body to path on server, using the same per-server
+// scheme/auth/TLS settings as every other HTTP path in ollamanager.
+func postToBackend(server *OllamaServer, path string, body []byte) (*http.Response, error) {
+	client := buildHTTPClient(server)
+	return client.Post(serverBaseURL(server)+path, "application/json", bytes.NewReader(body))
+}
+
+// handleDaemonGenerateOrChat dispatches /api/generate and /api/chat to a
+// backend that has the requested model, streaming the backend's NDJSON
+// response straight back to the client.
+func handleDaemonGenerateOrChat(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req modelRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	selected, err := theFarm.Select(farm.Where{Model: req.modelName(), OnlineOnly: true})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	upstream, err := postToBackend(backendFor(selected), r.URL.Path, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer upstream.Body.Close()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(upstream.StatusCode)
+
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := upstream.Body.Read(buf)
+		if n > 0 {
+			w.Write(buf[:n])
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+}
+
+// handleDaemonProxy forwards a request verbatim to the backend selected
+// for its requested model. The backend's response body is copied through
+// without flushing after each chunk, so a streamed response (such as
+// /api/pull progress) is not forwarded line by line.
+func handleDaemonProxy(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var req modelRequest
+		json.Unmarshal(body, &req)
+
+		selected, err := theFarm.Select(farm.Where{Model: req.modelName(), OnlineOnly: true})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		upstream, err := postToBackend(backendFor(selected), r.URL.Path, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer upstream.Body.Close()
+
+		w.WriteHeader(upstream.StatusCode)
+		io.Copy(w, upstream.Body)
+	}
+}
+
+// taggedModel is a single entry in the aggregated /api/tags response, with
+// the owning server's name attached so clients know where it lives.
+type taggedModel struct {
+	Name   string `json:"name"`
+	Server string `json:"server"`
+}
+
+// handleDaemonTags aggregates /api/tags across every known backend.
+func handleDaemonTags(w http.ResponseWriter, r *http.Request) {
+	var models []taggedModel
+	for _, s := range theFarm.List() {
+		for _, m := range s.Models {
+			models = append(models, taggedModel{Name: m, Server: s.Name})
+		}
+	}
+	writeJSON(w, map[string]any{"models": models})
+}
+
+// handleDaemonVersion reports the oldest (lowest) version among online
+// backends, matching ollama's convention of reporting a single version.
+func handleDaemonVersion(w http.ResponseWriter, r *http.Request) {
+	v := ""
+	for _, s := range theFarm.List() {
+		if s.Online && (v == "" || s.Version < v) {
+			v = s.Version
+		}
+	}
+	writeJSON(w, map[string]string{"version": v})
+}
+
+// handleDaemonOwnVersion reports ollamanager's own version, distinct from
+// any backend's ollama version.
+func handleDaemonOwnVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{"ollamanager": version})
+}
+
+// handleDaemonModels reports ollamanager's own metadata plus the union of
+// backend inventories.
+func handleDaemonModels(w http.ResponseWriter, r *http.Request) {
+	var models []taggedModel
+	for _, s := range theFarm.List() {
+		for _, m := range s.Models {
+			models = append(models, taggedModel{Name: m, Server: s.Name})
+		}
+	}
+	writeJSON(w, map[string]any{
+		"ollamanager": version,
+		"models":      models,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
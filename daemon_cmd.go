@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var daemonListen string
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run as an HTTP server exposing an aggregated Ollama API",
+	Long: `Run ollamanager as a long-lived HTTP server speaking the Ollama REST API.
+Requests are dispatched to whichever configured backend has the requested
+model, so clients pointed at ollamanager transparently reach the right
+server.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ensureFarm()
+		runDaemon(daemonListen)
+	},
+}
+
+func init() {
+	daemonCmd.Flags().StringVar(&daemonListen, "listen", ":8080", "address to listen on")
+}
@@ -0,0 +1,440 @@
+// Package farm turns a set of independent Ollama backends into a single
+// pool that can be queried for "the best server that can handle this
+// request". It owns health-checking and model-inventory discovery for
+// each registered backend and leaves request routing to the caller via
+// Select.
+package farm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxConsecutiveFailures is how many heartbeats in a row must fail before a
+// server is marked offline. A single blip (one dropped heartbeat) should not
+// pull a healthy server out of rotation.
+const maxConsecutiveFailures = 3
+
+// pollTimeout bounds every health/inventory request a poll makes,
+// regardless of which http.Client is in play. It can't rely on the
+// client's own Timeout: per-server clients built for auth/TLS settings
+// intentionally leave that unset, since the same client is also used for
+// the REPL's long-lived streaming requests.
+const pollTimeout = 5 * time.Second
+
+// Server is a single backend registered with the Farm, together with the
+// health and inventory metadata the Farm uses to make routing decisions.
+type Server struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Scheme  string `json:"scheme,omitempty"`
+	Group   string `json:"group,omitempty"`
+	Weight  int    `json:"weight"`
+
+	Online   bool          `json:"online"`
+	LastSeen time.Time     `json:"lastSeen"`
+	Latency  time.Duration `json:"latency"`
+	Version  string        `json:"version"`
+	Models   []string      `json:"models"`
+
+	// client is used for this server's health/inventory polling instead of
+	// the Farm's default client when set, so per-server auth and TLS
+	// settings (which the farm package knows nothing about) are honored.
+	client *http.Client
+
+	consecutiveFailures int
+}
+
+func (s *Server) scheme() string {
+	if s.Scheme == "" {
+		return "http"
+	}
+	return s.Scheme
+}
+
+// HasModel reports whether the server's known inventory includes model.
+func (s *Server) HasModel(model string) bool {
+	for _, m := range s.Models {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+// Where filters candidate servers for Farm.Select. Zero values are
+// wildcards: an empty Model matches any server, etc.
+type Where struct {
+	Model      string
+	Group      string
+	MinVersion string
+	OnlineOnly bool
+}
+
+// Farm tracks a set of Ollama backends, polling each on an interval for
+// health (via /api/version) and model inventory (via /api/tags).
+type Farm struct {
+	mu         sync.RWMutex
+	servers    map[string]*Server
+	interval   time.Duration
+	client     *http.Client
+	roundRobin map[string]int
+	statePath  string
+
+	stop chan struct{}
+}
+
+// New creates a Farm that polls its backends every interval. statePath, if
+// non-empty, is where farm state is persisted between runs (see Save/Load).
+func New(interval time.Duration, statePath string) *Farm {
+	return &Farm{
+		servers:    make(map[string]*Server),
+		interval:   interval,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		roundRobin: make(map[string]int),
+		statePath:  statePath,
+	}
+}
+
+// Register adds or updates a backend in the farm. Calling it again for an
+// existing name updates its address/scheme/group/weight/client but keeps
+// its last known health and inventory until the next poll. client, if
+// non-nil, is used for this server's health/inventory polling instead of
+// the farm's default client, so servers behind auth or custom TLS settings
+// can still be polled; pass nil to use the default.
+func (f *Farm) Register(name, address, scheme, group string, weight int, client *http.Client) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if s, ok := f.servers[name]; ok {
+		s.Address = address
+		s.Scheme = scheme
+		s.Group = group
+		s.Weight = weight
+		s.client = client
+		return
+	}
+	f.servers[name] = &Server{
+		Name:    name,
+		Address: address,
+		Scheme:  scheme,
+		Group:   group,
+		Weight:  weight,
+		client:  client,
+	}
+}
+
+// Remove drops a backend from the farm.
+func (f *Farm) Remove(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.servers, name)
+}
+
+// List returns a snapshot of all registered servers, sorted by name.
+func (f *Farm) List() []Server {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	out := make([]Server, 0, len(f.servers))
+	for _, s := range f.servers {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Start polls every server once, returning after that first poll, and then
+// launches the background polling loop; call Stop to shut the loop down.
+func (f *Farm) Start() {
+	f.mu.Lock()
+	if f.stop != nil {
+		f.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	f.stop = stop
+	f.mu.Unlock()
+
+	f.PollAll()
+	go f.loop(stop)
+}
+
+// Stop halts the background polling loop.
+func (f *Farm) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stop != nil {
+		close(f.stop)
+		f.stop = nil
+	}
+}
+
+func (f *Farm) loop(stop <-chan struct{}) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			f.PollAll()
+		}
+	}
+}
+
+// PollAll probes every registered server once, concurrently, and updates
+// their health and inventory in place.
+func (f *Farm) PollAll() {
+	f.mu.RLock()
+	servers := make([]*Server, 0, len(f.servers))
+	for _, s := range f.servers {
+		servers = append(servers, s)
+	}
+	f.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, s := range servers {
+		wg.Add(1)
+		go func(s *Server) {
+			defer wg.Done()
+			f.poll(s)
+		}(s)
+	}
+	wg.Wait()
+
+	if f.statePath != "" {
+		if err := f.Save(f.statePath); err != nil {
+			fmt.Fprintf(os.Stderr, "farm: error saving state: %v\n", err)
+		}
+	}
+}
+
+func (f *Farm) poll(s *Server) {
+	client := f.client
+	if s.client != nil {
+		client = s.client
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+	defer cancel()
+
+	start := time.Now()
+	version, err := fetchVersion(ctx, client, s.scheme(), s.Address)
+	if err != nil {
+		f.mu.Lock()
+		s.consecutiveFailures++
+		if s.consecutiveFailures >= maxConsecutiveFailures {
+			s.Online = false
+		}
+		f.mu.Unlock()
+		return
+	}
+
+	models, err := fetchModels(ctx, client, s.scheme(), s.Address)
+	if err != nil {
+		f.mu.Lock()
+		s.consecutiveFailures++
+		if s.consecutiveFailures >= maxConsecutiveFailures {
+			s.Online = false
+		}
+		f.mu.Unlock()
+		return
+	}
+
+	f.mu.Lock()
+	s.Online = true
+	s.consecutiveFailures = 0
+	s.Version = version
+	s.Models = models
+	s.Latency = time.Since(start)
+	s.LastSeen = time.Now()
+	f.mu.Unlock()
+}
+
+type versionResponse struct {
+	Version string `json:"version"`
+}
+
+func fetchVersion(ctx context.Context, client *http.Client, scheme, address string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/api/version", scheme, address), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	var v versionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return "", err
+	}
+	return v.Version, nil
+}
+
+type tagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+func fetchModels(ctx context.Context, client *http.Client, scheme, address string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/api/tags", scheme, address), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	var t tagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(t.Models))
+	for _, m := range t.Models {
+		names = append(names, m.Name)
+	}
+	return names, nil
+}
+
+// Select returns the best-fit backend for w, or an error if none match.
+// Candidates are filtered by w and then ranked by: online first, then
+// has-the-requested-model, then lowest latency, then highest weight. Ties
+// are broken by round-robin within the matching group.
+func (f *Farm) Select(w Where) (*Server, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var candidates []*Server
+	for _, s := range f.servers {
+		if w.OnlineOnly && !s.Online {
+			continue
+		}
+		if w.Group != "" && s.Group != w.Group {
+			continue
+		}
+		if w.Model != "" && !s.HasModel(w.Model) {
+			continue
+		}
+		if w.MinVersion != "" && s.Version != "" && s.Version < w.MinVersion {
+			continue
+		}
+		candidates = append(candidates, s)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no server matches model=%q group=%q", w.Model, w.Group)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Online != b.Online {
+			return a.Online
+		}
+		aHas, bHas := a.HasModel(w.Model), b.HasModel(w.Model)
+		if aHas != bHas {
+			return aHas
+		}
+		if a.Latency != b.Latency {
+			return a.Latency < b.Latency
+		}
+		return a.Weight > b.Weight
+	})
+
+	// Among servers tied with the best candidate, round-robin within the group.
+	best := candidates[0]
+	var tied []*Server
+	for _, c := range candidates {
+		if c.Online == best.Online && c.HasModel(w.Model) == best.HasModel(w.Model) &&
+			c.Latency == best.Latency && c.Weight == best.Weight {
+			tied = append(tied, c)
+		}
+	}
+	if len(tied) > 1 {
+		sort.Slice(tied, func(i, j int) bool { return tied[i].Name < tied[j].Name })
+		idx := f.roundRobin[w.Group] % len(tied)
+		f.roundRobin[w.Group]++
+		return tied[idx], nil
+	}
+
+	return best, nil
+}
+
+// state is the on-disk representation written next to config.json.
+type state struct {
+	Servers []Server `json:"servers"`
+}
+
+// Save writes the farm's current server list (including last known health
+// and inventory) to path.
+func (f *Farm) Save(path string) error {
+	f.mu.RLock()
+	st := state{Servers: make([]Server, 0, len(f.servers))}
+	for _, s := range f.servers {
+		st.Servers = append(st.Servers, *s)
+	}
+	f.mu.RUnlock()
+
+	sort.Slice(st.Servers, func(i, j int) bool { return st.Servers[i].Name < st.Servers[j].Name })
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating farm state file: %v", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(st); err != nil {
+		return fmt.Errorf("error encoding farm state file: %v", err)
+	}
+	return nil
+}
+
+// Load reads previously persisted farm state from path, merging it into
+// the farm's in-memory servers (matched by name). It is not an error for
+// path to not exist yet.
+func (f *Farm) Load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error opening farm state file: %v", err)
+	}
+	defer file.Close()
+
+	var st state
+	if err := json.NewDecoder(file).Decode(&st); err != nil {
+		return fmt.Errorf("error decoding farm state file: %v", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, saved := range st.Servers {
+		if s, ok := f.servers[saved.Name]; ok {
+			s.Online = saved.Online
+			s.LastSeen = saved.LastSeen
+			s.Latency = saved.Latency
+			s.Version = saved.Version
+			s.Models = saved.Models
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ollamanager/farm"
+)
+
+// farmPollInterval controls how often the farm re-checks each backend's
+// health and model inventory.
+const farmPollInterval = 30 * time.Second
+
+// theFarm is the process-wide farm of registered backends, built from
+// config.Servers by ensureFarm on first use. Servers added to or removed
+// from config later in the same process are not registered with it.
+var theFarm *farm.Farm
+
+var farmCmd = &cobra.Command{
+	Use:   "farm",
+	Short: "Inspect and query the farm of registered servers",
+}
+
+var farmStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show health and inventory for every registered server",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ensureFarm()
+		printFarmStatus()
+	},
+}
+
+var whereFilter farm.Where
+var whereIncludeOffline bool
+
+var farmWhereCmd = &cobra.Command{
+	Use:   "where",
+	Short: "Print the best-fit server for the given filters",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ensureFarm()
+		whereFilter.OnlineOnly = !whereIncludeOffline
+		s, err := theFarm.Select(whereFilter)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("%s (%s)\n", s.Name, s.Address)
+	},
+}
+
+func init() {
+	farmWhereCmd.Flags().StringVar(&whereFilter.Model, "model", "", "only consider servers that already have this model pulled")
+	farmWhereCmd.Flags().StringVar(&whereFilter.Group, "group", "", "only consider servers in this group")
+	farmWhereCmd.Flags().StringVar(&whereFilter.MinVersion, "min-version", "", "only consider servers running at least this ollama version")
+	farmWhereCmd.Flags().BoolVar(&whereIncludeOffline, "include-offline", false, "also consider servers currently marked offline")
+
+	farmCmd.AddCommand(farmStatusCmd, farmWhereCmd)
+}
+
+var farmInitOnce sync.Once
+
+// ensureFarm lazily builds the process-wide farm on first use. initFarm's
+// first poll blocks until every configured backend answers (or times
+// out), so commands that don't need farm data (server list, version,
+// completion, ...) must never trigger it; only run --any, farm status,
+// farm where, and daemon do.
+func ensureFarm() {
+	farmInitOnce.Do(initFarm)
+}
+
+// initFarm builds the process-wide farm from the configured servers and
+// starts its background health/inventory polling.
+func initFarm() {
+	theFarm = farm.New(farmPollInterval, farmStatePath())
+	for i := range config.Servers {
+		s := &config.Servers[i]
+		theFarm.Register(s.Name, s.Address, serverScheme(s), s.Group, s.Weight, farmClientFor(s))
+	}
+	if err := theFarm.Load(farmStatePath()); err != nil {
+		fmt.Printf("Warning: could not load farm state: %v\n", err)
+	}
+	theFarm.Start()
+}
+
+// farmStatePath returns where farm health/inventory state is persisted,
+// next to the main config file.
+func farmStatePath() string {
+	return filepath.Join(filepath.Dir(config.ConfigPath), "farm.json")
+}
+
+// farmClientFor returns the *http.Client the farm should use to poll s, or
+// nil to fall back to the farm's plain default client. A server only needs
+// its own client when it has auth or TLS settings the farm's default
+// client can't express. Either way, each poll is bounded by the farm's own
+// per-poll timeout.
+func farmClientFor(s *OllamaServer) *http.Client {
+	if needsAuthProxy(s) || s.CACertPath != "" || s.InsecureSkipVerify {
+		return buildHTTPClient(s)
+	}
+	return nil
+}
+
+func printFarmStatus() {
+	servers := theFarm.List()
+	if len(servers) == 0 {
+		fmt.Println("No servers registered in the farm")
+		return
+	}
+
+	fmt.Println("Farm status:")
+	for _, s := range servers {
+		status := "offline"
+		if s.Online {
+			status = "online"
+		}
+		fmt.Printf("  %s (%s) [%s] group=%s weight=%d models=%d latency=%s\n",
+			s.Name, s.Address, status, s.Group, s.Weight, len(s.Models), s.Latency)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"ollamanager/ollamaclient"
+)
+
+// defaultServerTimeout is used when a server doesn't set TimeoutSeconds.
+const defaultServerTimeout = 30 * time.Second
+
+// serverScheme returns the server's configured scheme, defaulting to http.
+func serverScheme(s *OllamaServer) string {
+	if s.Scheme == "" {
+		return "http"
+	}
+	return s.Scheme
+}
+
+// serverBaseURL returns the server's scheme://address base URL.
+func serverBaseURL(s *OllamaServer) string {
+	return fmt.Sprintf("%s://%s", serverScheme(s), s.Address)
+}
+
+// clientForServer builds an ollamaclient.Client that honors a server's
+// auth, TLS, and connection settings.
+func clientForServer(s *OllamaServer) *ollamaclient.Client {
+	return ollamaclient.NewWithClient(serverScheme(s), s.Address, buildHTTPClient(s))
+}
+
+// buildHTTPClient builds a *http.Client that applies a server's configured
+// auth headers and TLS settings to every request. TimeoutSeconds bounds
+// only the initial connect, not the overall request: callers like `run`'s
+// chat REPL stream for as long as the model keeps generating, and a
+// client-wide http.Client.Timeout would cut that off mid-stream.
+func buildHTTPClient(s *OllamaServer) *http.Client {
+	dialTimeout := defaultServerTimeout
+	if s.TimeoutSeconds > 0 {
+		dialTimeout = time.Duration(s.TimeoutSeconds) * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout}).DialContext
+	if tlsConfig, err := buildTLSConfig(s); err == nil {
+		transport.TLSClientConfig = tlsConfig
+	} else {
+		fmt.Printf("Warning: could not build TLS config for server '%s': %v\n", s.Name, err)
+	}
+
+	return &http.Client{
+		Transport: &authRoundTripper{base: transport, server: s},
+	}
+}
+
+// buildTLSConfig applies CACertPath/InsecureSkipVerify to a tls.Config; it
+// is a no-op (nil config) when neither is set.
+func buildTLSConfig(s *OllamaServer) (*tls.Config, error) {
+	if s.CACertPath == "" && !s.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: s.InsecureSkipVerify}
+
+	if s.CACertPath != "" {
+		pem, err := os.ReadFile(s.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA cert: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", s.CACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// authRoundTripper injects a server's bearer token, basic auth, and custom
+// headers into every outgoing request before handing it to base.
+type authRoundTripper struct {
+	base   http.RoundTripper
+	server *OllamaServer
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	if rt.server.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+rt.server.BearerToken)
+	}
+	if rt.server.BasicAuthUser != "" {
+		req.SetBasicAuth(rt.server.BasicAuthUser, rt.server.BasicAuthPass)
+	}
+	for k, v := range rt.server.Headers {
+		req.Header.Set(k, v)
+	}
+
+	return rt.base.RoundTrip(req)
+}
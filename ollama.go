@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+
+	"ollamanager/farm"
+)
+
+// knownVerbs are the ollama verbs ollamanager understands well enough to
+// dispatch natively through ollamaclient instead of shelling out to a
+// local `ollama` binary.
+var knownVerbs = map[string]bool{
+	"run": true, "pull": true, "list": true, "ps": true,
+	"show": true, "rm": true, "create": true,
+}
+
+// Ollama command execution
+func runOllamaCommand(command string, args []string) {
+	server := getCurrentServer()
+	if server == nil {
+		fmt.Println("No current server selected")
+		return
+	}
+	dispatch(server, command, args)
+}
+
+// runOllamaCommandAny behaves like runOllamaCommand, but picks any healthy
+// farm server that already has the requested model instead of only using
+// config.Current. model is best-effort extracted from args[0], since that
+// is where `run`/`pull`/`show` take it; if it can't be determined, any
+// online server is used.
+func runOllamaCommandAny(command string, args []string) {
+	ensureFarm()
+
+	model := ""
+	if len(args) > 0 {
+		model = args[0]
+	}
+
+	selected, err := theFarm.Select(farm.Where{Model: model, OnlineOnly: true})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	server := serverByName(selected.Name)
+	if server == nil {
+		// Farm state outlived a server being removed from config; fall back
+		// to what the farm told us rather than failing outright.
+		server = &OllamaServer{Name: selected.Name, Address: selected.Address, Scheme: selected.Scheme}
+	}
+
+	dispatch(server, command, args)
+}
+
+// dispatch runs command against server, using the native ollamaclient for
+// verbs ollamanager understands and falling back to the local `ollama`
+// binary (via OLLAMA_HOST) for anything else.
+func dispatch(server *OllamaServer, command string, args []string) {
+	if !knownVerbs[command] {
+		execFallback(server, command, args)
+		return
+	}
+
+	client := clientForServer(server)
+
+	// runREPL manages its own per-turn Ctrl-C cancellation (see repl.go),
+	// since a single request-scoped context would stay canceled for every
+	// turn after the first Ctrl-C.
+	if command == "run" {
+		if err := runREPL(context.Background(), client, firstArg(args)); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		return
+	}
+
+	// A Ctrl-C cancels the in-flight request for every other verb; they're
+	// all single-shot, so there's no "next turn" to preserve.
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+	defer func() {
+		signal.Stop(interrupt)
+		cancel()
+	}()
+
+	var err error
+	switch command {
+	case "pull":
+		err = pullModel(ctx, client, firstArg(args))
+	case "list":
+		err = listModels(ctx, client)
+	case "ps":
+		err = listRunning(ctx, client)
+	case "show":
+		err = showModel(ctx, client, firstArg(args))
+	case "rm":
+		err = removeModel(ctx, client, firstArg(args))
+	case "create":
+		err = createModel(ctx, client, args)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// execFallback shells out to a local `ollama` binary, for verbs we don't
+// have a native implementation for. The `ollama` binary only understands
+// OLLAMA_HOST; if the server needs a bearer token, basic auth, or custom
+// headers, those can't be expressed that way, so we front it with a local
+// reverse proxy that injects them and point OLLAMA_HOST at the proxy
+// instead.
+func execFallback(server *OllamaServer, command string, args []string) {
+	host := server.Address
+	if serverScheme(server) == "https" {
+		host = "https://" + host
+	}
+
+	if needsAuthProxy(server) {
+		proxyAddr, stop, err := startAuthProxy(server)
+		if err != nil {
+			fmt.Printf("Error starting auth proxy for server '%s': %v\n", server.Name, err)
+			return
+		}
+		defer stop()
+		host = proxyAddr
+	}
+
+	os.Setenv("OLLAMA_HOST", host)
+
+	cmd := exec.Command("ollama", append([]string{command}, args...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin // Allow for interactive input
+
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("Error executing ollama command: %v\n", err)
+	}
+}
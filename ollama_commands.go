@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"ollamanager/ollamaclient"
+)
+
+func pullModel(ctx context.Context, client *ollamaclient.Client, model string) error {
+	if model == "" {
+		return fmt.Errorf("usage: ollamanager pull <model>")
+	}
+
+	return client.Pull(ctx, model, func(p ollamaclient.PullProgress) error {
+		if p.Total > 0 {
+			pct := float64(p.Completed) / float64(p.Total) * 100
+			fmt.Printf("\r%s: %.1f%%", p.Status, pct)
+			if p.Completed >= p.Total {
+				fmt.Println()
+			}
+		} else {
+			fmt.Println(p.Status)
+		}
+		return nil
+	})
+}
+
+func listModels(ctx context.Context, client *ollamaclient.Client) error {
+	models, err := client.Tags(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-40s %-12s %s\n", "NAME", "SIZE", "MODIFIED")
+	for _, m := range models {
+		fmt.Printf("%-40s %-12d %s\n", m.Name, m.Size, m.ModifiedAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func listRunning(ctx context.Context, client *ollamaclient.Client) error {
+	models, err := client.Ps(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(models) == 0 {
+		fmt.Println("No models are currently running")
+		return nil
+	}
+
+	fmt.Printf("%-40s %-12s %s\n", "NAME", "SIZE", "EXPIRES")
+	for _, m := range models {
+		fmt.Printf("%-40s %-12d %s\n", m.Name, m.Size, m.ExpiresAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func showModel(ctx context.Context, client *ollamaclient.Client, model string) error {
+	if model == "" {
+		return fmt.Errorf("usage: ollamanager show <model>")
+	}
+
+	info, err := client.Show(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Modelfile:")
+	fmt.Println(info.Modelfile)
+	if info.Parameters != "" {
+		fmt.Println("Parameters:")
+		fmt.Println(info.Parameters)
+	}
+	return nil
+}
+
+func removeModel(ctx context.Context, client *ollamaclient.Client, model string) error {
+	if model == "" {
+		return fmt.Errorf("usage: ollamanager rm <model>")
+	}
+
+	if err := client.Delete(ctx, model); err != nil {
+		return err
+	}
+	fmt.Printf("Removed model '%s'\n", model)
+	return nil
+}
+
+// createModel implements `ollamanager create <name> -f <Modelfile>`.
+func createModel(ctx context.Context, client *ollamaclient.Client, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: ollamanager create <name> -f <Modelfile>")
+	}
+
+	name := args[0]
+	modelfilePath := ""
+	for i := 1; i < len(args); i++ {
+		if (args[i] == "-f" || args[i] == "--file") && i+1 < len(args) {
+			modelfilePath = args[i+1]
+			i++
+		}
+	}
+	if modelfilePath == "" {
+		return fmt.Errorf("usage: ollamanager create <name> -f <Modelfile>")
+	}
+
+	return client.Create(ctx, name, modelfilePath, func(p ollamaclient.PullProgress) error {
+		fmt.Println(p.Status)
+		return nil
+	})
+}
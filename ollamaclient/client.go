@@ -0,0 +1,358 @@
+// Package ollamaclient speaks the Ollama HTTP API directly, so ollamanager
+// can talk to a remote server without requiring a local `ollama` binary.
+package ollamaclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Client talks to a single Ollama server over HTTP.
+type Client struct {
+	Scheme     string
+	Address    string
+	HTTPClient *http.Client
+}
+
+// New returns a Client for the backend at address (host:port, no scheme),
+// using plain HTTP and no auth.
+func New(address string) *Client {
+	return NewWithClient("http", address, &http.Client{Timeout: 0}) // streaming endpoints can run arbitrarily long
+}
+
+// NewWithClient returns a Client for address using scheme ("http" or
+// "https") and httpClient, so callers can supply auth headers, TLS
+// settings, or a custom timeout.
+func NewWithClient(scheme, address string, httpClient *http.Client) *Client {
+	return &Client{Scheme: scheme, Address: address, HTTPClient: httpClient}
+}
+
+func (c *Client) url(path string) string {
+	return fmt.Sprintf("%s://%s%s", c.Scheme, c.Address, path)
+}
+
+// Message is a single turn in a chat conversation.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatRequest is the body of POST /api/chat.
+type ChatRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+}
+
+// ChatChunk is one line of the /api/chat NDJSON response stream.
+type ChatChunk struct {
+	Model      string  `json:"model"`
+	Message    Message `json:"message"`
+	Done       bool    `json:"done"`
+	DoneReason string  `json:"done_reason,omitempty"`
+}
+
+// Chat streams a chat completion, invoking onChunk for every NDJSON line
+// until the server sends Done or ctx is canceled.
+func (c *Client) Chat(ctx context.Context, req ChatRequest, onChunk func(ChatChunk) error) error {
+	req.Stream = true
+	return c.streamPost(ctx, "/api/chat", req, func(line []byte) error {
+		var chunk ChatChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return err
+		}
+		return onChunk(chunk)
+	})
+}
+
+// GenerateRequest is the body of POST /api/generate.
+type GenerateRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+}
+
+// GenerateChunk is one line of the /api/generate NDJSON response stream.
+type GenerateChunk struct {
+	Model    string `json:"model"`
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+}
+
+// Generate streams a text completion, invoking onChunk for every NDJSON
+// line until the server sends Done or ctx is canceled.
+func (c *Client) Generate(ctx context.Context, req GenerateRequest, onChunk func(GenerateChunk) error) error {
+	req.Stream = true
+	return c.streamPost(ctx, "/api/generate", req, func(line []byte) error {
+		var chunk GenerateChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return err
+		}
+		return onChunk(chunk)
+	})
+}
+
+// PullProgress is one line of the /api/pull NDJSON progress stream.
+type PullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+// Pull downloads model, invoking onProgress for every reported status line.
+func (c *Client) Pull(ctx context.Context, model string, onProgress func(PullProgress) error) error {
+	body := struct {
+		Model  string `json:"model"`
+		Stream bool   `json:"stream"`
+	}{Model: model, Stream: true}
+
+	return c.streamPost(ctx, "/api/pull", body, func(line []byte) error {
+		var p PullProgress
+		if err := json.Unmarshal(line, &p); err != nil {
+			return err
+		}
+		return onProgress(p)
+	})
+}
+
+// Model is a single entry returned by /api/tags.
+type Model struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	Digest     string    `json:"digest"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// Tags lists the models available on the server.
+func (c *Client) Tags(ctx context.Context) ([]Model, error) {
+	var out struct {
+		Models []Model `json:"models"`
+	}
+	if err := c.getJSON(ctx, "/api/tags", &out); err != nil {
+		return nil, err
+	}
+	return out.Models, nil
+}
+
+// RunningModel is a single entry returned by /api/ps.
+type RunningModel struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Ps lists the models currently loaded in memory on the server.
+func (c *Client) Ps(ctx context.Context) ([]RunningModel, error) {
+	var out struct {
+		Models []RunningModel `json:"models"`
+	}
+	if err := c.getJSON(ctx, "/api/ps", &out); err != nil {
+		return nil, err
+	}
+	return out.Models, nil
+}
+
+// ShowInfo is the response from /api/show.
+type ShowInfo struct {
+	Modelfile  string `json:"modelfile"`
+	Parameters string `json:"parameters"`
+	Template   string `json:"template"`
+}
+
+// Show returns detailed information about a single model.
+func (c *Client) Show(ctx context.Context, model string) (ShowInfo, error) {
+	var out ShowInfo
+	body := struct {
+		Name string `json:"name"`
+	}{Name: model}
+	err := c.postJSON(ctx, "/api/show", body, &out)
+	return out, err
+}
+
+// Delete removes a model from the server.
+func (c *Client) Delete(ctx context.Context, model string) error {
+	body, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{Name: model})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.url("/api/delete"), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete %s: unexpected status %d (%s)", model, resp.StatusCode, string(b))
+	}
+	return nil
+}
+
+// Create uploads a Modelfile and creates a new model named name from it,
+// invoking onProgress for every reported status line.
+func (c *Client) Create(ctx context.Context, name, modelfilePath string, onProgress func(PullProgress) error) error {
+	modelfile, err := os.ReadFile(modelfilePath)
+	if err != nil {
+		return fmt.Errorf("error reading modelfile: %v", err)
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("name", name); err != nil {
+		return err
+	}
+	part, err := mw.CreateFormFile("modelfile", "Modelfile")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(modelfile); err != nil {
+		return err
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url("/api/create"), &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("create %s: unexpected status %d (%s)", name, resp.StatusCode, string(b))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var p PullProgress
+		if err := json.Unmarshal(line, &p); err != nil {
+			return err
+		}
+		if err := onProgress(p); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Version returns the server's reported ollama version.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var out struct {
+		Version string `json:"version"`
+	}
+	if err := c.getJSON(ctx, "/api/version", &out); err != nil {
+		return "", err
+	}
+	return out.Version, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(path), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: unexpected status %d (%s)", path, resp.StatusCode, string(b))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *Client) postJSON(ctx context.Context, path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(path), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: unexpected status %d (%s)", path, resp.StatusCode, string(b))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// streamPost POSTs in as JSON and calls onLine for every non-empty line of
+// the NDJSON response, stopping early if ctx is canceled.
+func (c *Client) streamPost(ctx context.Context, path string, in any, onLine func(line []byte) error) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(path), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: unexpected status %d (%s)", path, resp.StatusCode, string(b))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := onLine(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
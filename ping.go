@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// pingWorkers bounds how many servers are probed concurrently, so pinging
+// a large fleet doesn't open an unbounded number of sockets at once.
+const pingWorkers = 8
+
+// PingResult is the outcome of probing a single server.
+type PingResult struct {
+	Name         string        `json:"name"`
+	Address      string        `json:"address"`
+	Online       bool          `json:"online"`
+	ErrorClass   string        `json:"errorClass,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Latency      time.Duration `json:"-"`
+	LatencyMs    int64         `json:"latencyMs"`
+	Version      string        `json:"version,omitempty"`
+	ModelCount   int           `json:"modelCount"`
+	RunningCount int           `json:"runningCount"`
+}
+
+// pingServers probes servers concurrently, bounded by pingWorkers, and
+// returns results in the same order as servers.
+func pingServers(servers []OllamaServer, timeout time.Duration) []PingResult {
+	results := make([]PingResult, len(servers))
+
+	sem := make(chan struct{}, pingWorkers)
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, s OllamaServer) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = pingOne(s, timeout)
+		}(i, s)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func pingOne(server OllamaServer, timeout time.Duration) PingResult {
+	result := PingResult{Name: server.Name, Address: server.Address}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := clientForServer(&server)
+
+	start := time.Now()
+	version, err := client.Version(ctx)
+	if err != nil {
+		result.ErrorClass, result.Error = classifyError(err)
+		return result
+	}
+	result.Online = true
+	result.Version = version
+	result.Latency = time.Since(start)
+	result.LatencyMs = result.Latency.Milliseconds()
+
+	if models, err := client.Tags(ctx); err == nil {
+		result.ModelCount = len(models)
+	}
+	if running, err := client.Ps(ctx); err == nil {
+		result.RunningCount = len(running)
+	}
+
+	return result
+}
+
+// classifyError sorts the usual connection failure modes into a short
+// class label, so a failed ping is more actionable than a single opaque
+// error string.
+func classifyError(err error) (class, detail string) {
+	detail = err.Error()
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return "dns", detail
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return "connect-refused", detail
+	}
+
+	switch {
+	case strings.Contains(detail, "tls:"), strings.Contains(detail, "certificate"):
+		return "tls", detail
+	case strings.Contains(detail, "unexpected status"):
+		return "http-status", detail
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout", detail
+	default:
+		return "unknown", detail
+	}
+}
+
+func printPingTable(results []PingResult) {
+	fmt.Printf("%-12s %-22s %-8s %-10s %-10s %-7s %s\n", "NAME", "ADDRESS", "STATUS", "LATENCY", "VERSION", "MODELS", "RUNNING")
+	for _, r := range results {
+		status := "online"
+		extra := ""
+		if !r.Online {
+			status = "offline"
+			extra = fmt.Sprintf(" (%s: %s)", r.ErrorClass, r.Error)
+		}
+		fmt.Printf("%-12s %-22s %-8s %-10s %-10s %-7d %d%s\n",
+			r.Name, r.Address, status, r.Latency.Round(time.Millisecond), r.Version, r.ModelCount, r.RunningCount, extra)
+	}
+}
+
+func printPingJSON(results []PingResult) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(results)
+}
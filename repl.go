@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"ollamanager/ollamaclient"
+)
+
+// runREPL implements `ollamanager run <model>`: an interactive chat loop
+// over the streaming /api/chat endpoint. Line editing is whatever the
+// terminal's cooked mode gives us via bufio.Scanner; a Ctrl-C during
+// generation cancels that one turn without killing the REPL.
+func runREPL(ctx context.Context, client *ollamaclient.Client, model string) error {
+	if model == "" {
+		return fmt.Errorf("usage: ollamanager run <model>")
+	}
+
+	fmt.Printf(">>> Chatting with %s. Send an empty line or Ctrl-D to exit.\n", model)
+
+	var history []ollamaclient.Message
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for {
+		fmt.Print(">>> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return scanner.Err()
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			return nil
+		}
+
+		history = append(history, ollamaclient.Message{Role: "user", Content: line})
+
+		reply, err := chatTurn(ctx, client, model, history)
+		if err != nil {
+			return err
+		}
+		if reply == nil {
+			fmt.Println("(generation canceled)")
+			continue
+		}
+
+		history = append(history, *reply)
+	}
+}
+
+// chatTurn streams one assistant reply, canceling the request (and
+// returning a nil reply) if Ctrl-C arrives before it finishes.
+func chatTurn(ctx context.Context, client *ollamaclient.Client, model string, history []ollamaclient.Message) (*ollamaclient.Message, error) {
+	turnCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+
+	var reply strings.Builder
+	err := client.Chat(turnCtx, ollamaclient.ChatRequest{Model: model, Messages: history}, func(chunk ollamaclient.ChatChunk) error {
+		fmt.Print(chunk.Message.Content)
+		reply.WriteString(chunk.Message.Content)
+		return nil
+	})
+	fmt.Println()
+
+	if errors.Is(err, context.Canceled) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ollamaclient.Message{Role: "assistant", Content: reply.String()}
+	return &msg, nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags bound by the root command and read by subcommands.
+var (
+	serverOverride string
+	configPathFlag string
+	jsonOutput     bool
+	anyServer      bool
+)
+
+// rootCmd is the ollamanager command tree. Any first argument that doesn't
+// match a registered subcommand (run, pull, list, ps, create, show, ...)
+// falls through to Run, which dispatches it against the selected server:
+// natively over the Ollama API for the verbs in knownVerbs, and through the
+// local ollama binary for anything else. This is what lets
+// `ollamanager run llama2` keep working without us having to register every
+// ollama verb as its own subcommand.
+var rootCmd = &cobra.Command{
+	Use:   appName,
+	Short: description,
+	Long: description + `
+
+Any command not listed below (run, pull, list, ps, create, show, ...) is
+sent to the current server: run, pull, list, ps, show, rm and create are
+served directly over the Ollama API, and any other command is forwarded to
+the local ollama binary, so standard ollama usage keeps working through
+ollamanager.`,
+	Example: `  ollamanager server add remote1 192.168.1.100       Add server with default port (11434)
+  ollamanager server use remote1                     Switch to using remote1
+  ollamanager run llama2                             Run llama2 model on current server
+  ollamanager --any run llama2                       Run llama2 on any healthy server that has it
+  ollamanager list                                   List models on current server`,
+	// DisableFlagParsing: cobra only reaches this Run when the first
+	// argument didn't match a registered subcommand, i.e. it's a plain
+	// ollama verb being forwarded (run, pull, create, ...). Those verbs
+	// take their own flags (`create <name> -f <Modelfile>`, `run <model>
+	// --verbose`), which cobra's flag parser would otherwise swallow or
+	// reject as unknown. stripGlobalFlags pulls out ollamanager's own
+	// global flags before cobra ever sees argv, so nothing is left for
+	// this command's flag parser to choke on.
+	DisableFlagParsing: true,
+	SilenceUsage:       true,
+	Args:               cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+			cmd.Help()
+			return
+		}
+		if anyServer {
+			runOllamaCommandAny(args[0], args[1:])
+			return
+		}
+		runOllamaCommand(args[0], args[1:])
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&serverOverride, "server", "s", "", "use this server for this invocation only, without changing the current server")
+	rootCmd.PersistentFlags().StringVar(&configPathFlag, "config", "", "path to the ollamanager config file")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output machine-readable JSON where supported")
+	rootCmd.PersistentFlags().BoolVar(&anyServer, "any", false, "pick any healthy server that already has the requested model")
+
+	cobra.OnInitialize(func() {
+		initConfig()
+		if serverOverride != "" {
+			applyServerOverride(serverOverride)
+		}
+	})
+
+	rootCmd.AddCommand(serverCmd)
+	rootCmd.AddCommand(farmCmd)
+	rootCmd.AddCommand(daemonCmd)
+	rootCmd.AddCommand(versionCmd)
+}
+
+// applyServerOverride points config.Current at name for the lifetime of
+// this invocation only; unlike `server use`, it never calls saveConfig.
+func applyServerOverride(name string) {
+	if !serverExists(name) {
+		fmt.Printf("Warning: --server %q not found in config, using %s\n", name, config.Current)
+		return
+	}
+	config.Current = name
+}
+
+// stripGlobalFlags extracts ollamanager's own global flags (--server/-s,
+// --config, --json, --any) out of args and returns what's left, setting
+// the corresponding package-level vars as it goes. It runs before cobra
+// ever sees argv: rootCmd has DisableFlagParsing set so that a forwarded
+// ollama verb's own flags reach dispatch untouched, which means cobra
+// can no longer parse these out for us either.
+func stripGlobalFlags(args []string) []string {
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--any":
+			anyServer = true
+		case a == "--json":
+			jsonOutput = true
+		case a == "--server" || a == "-s":
+			if i+1 < len(args) {
+				serverOverride = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--server="):
+			serverOverride = strings.TrimPrefix(a, "--server=")
+		case a == "--config":
+			if i+1 < len(args) {
+				configPathFlag = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--config="):
+			configPathFlag = strings.TrimPrefix(a, "--config=")
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return rest
+}
+
+// Execute runs the root command tree; main() only needs to call this.
+func Execute() {
+	rootCmd.SetArgs(stripGlobalFlags(os.Args[1:]))
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Manage Ollama servers",
+	Long: `Manage the Ollama servers ollamanager knows about: add new ones, list
+them, switch the current server, remove one, or check connectivity.`,
+}
+
+var (
+	addScheme             string
+	addBearerToken        string
+	addBasicAuthUser      string
+	addBasicAuthPass      string
+	addCACertPath         string
+	addInsecureSkipVerify bool
+	addHeaders            []string
+	addTimeoutSeconds     int
+)
+
+var serverAddCmd = &cobra.Command{
+	Use:   "add <name> <address>",
+	Short: "Add a new Ollama server (address format: host:port or just host)",
+	Args:  cobra.ExactArgs(2),
+	Example: `  ollamanager server add remote1 192.168.1.100       Add server with default port (11434)
+  ollamanager server add remote2 192.168.1.101:8080  Add server with custom port
+  ollamanager server add secure proxy.internal:443 --scheme=https --bearer-token=$TOKEN`,
+	Run: func(cmd *cobra.Command, args []string) {
+		headers, err := parseHeaderFlags(addHeaders)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		addServer(OllamaServer{
+			Name:               args[0],
+			Address:            args[1],
+			Scheme:             addScheme,
+			BearerToken:        addBearerToken,
+			BasicAuthUser:      addBasicAuthUser,
+			BasicAuthPass:      addBasicAuthPass,
+			CACertPath:         addCACertPath,
+			InsecureSkipVerify: addInsecureSkipVerify,
+			Headers:            headers,
+			TimeoutSeconds:     addTimeoutSeconds,
+		})
+	},
+}
+
+// parseHeaderFlags turns repeated `--header=Key: Value` flags into a map.
+func parseHeaderFlags(raw []string) (map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	headers := make(map[string]string, len(raw))
+	for _, h := range raw {
+		key, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid --header %q, expected Key: Value", h)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+var serverListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all saved servers",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		listServers()
+	},
+}
+
+var serverUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch to a specific server",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useServer(args[0])
+	},
+}
+
+var serverRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a server",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		removeServer(args[0])
+	},
+}
+
+var serverCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show current server",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		showCurrentServer()
+	},
+}
+
+var (
+	pingAll     bool
+	pingTimeout time.Duration
+	pingWatch   time.Duration
+)
+
+var serverPingCmd = &cobra.Command{
+	Use:   "ping [name]",
+	Short: "Ping one or all servers to check connectivity and health",
+	Long: `Probe one server (by name, or the current server if none is given) or
+every configured server with --all, concurrently, and print a table of
+status, latency, ollama version, model count, and running model count.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		servers, err := pingTargets(args)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		runPing := func() {
+			results := pingServers(servers, pingTimeout)
+			if jsonOutput {
+				printPingJSON(results)
+			} else {
+				printPingTable(results)
+			}
+		}
+
+		if pingWatch <= 0 {
+			runPing()
+			return
+		}
+
+		for {
+			if jsonOutput {
+				runPing()
+				fmt.Println()
+			} else {
+				// \033[H\033[2J: move the cursor home and clear the
+				// screen, so each tick redraws the table in place
+				// instead of scrolling a new one in below the last.
+				fmt.Print("\033[H\033[2J")
+				runPing()
+			}
+			time.Sleep(pingWatch)
+		}
+	},
+}
+
+// pingTargets resolves the servers a `server ping` invocation should
+// probe: all of them with --all, a single named server, or the current
+// server if neither is given.
+func pingTargets(args []string) ([]OllamaServer, error) {
+	if pingAll {
+		return config.Servers, nil
+	}
+
+	name := config.Current
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	for _, s := range config.Servers {
+		if s.Name == name {
+			return []OllamaServer{s}, nil
+		}
+	}
+	return nil, fmt.Errorf("server with name '%s' not found", name)
+}
+
+func init() {
+	serverAddCmd.Flags().StringVar(&addScheme, "scheme", "http", "connection scheme (http or https)")
+	serverAddCmd.Flags().StringVar(&addBearerToken, "bearer-token", "", "bearer token sent as an Authorization header")
+	serverAddCmd.Flags().StringVar(&addBasicAuthUser, "basic-user", "", "HTTP basic auth username")
+	serverAddCmd.Flags().StringVar(&addBasicAuthPass, "basic-pass", "", "HTTP basic auth password")
+	serverAddCmd.Flags().StringVar(&addCACertPath, "ca-cert", "", "path to a PEM CA certificate to trust for this server")
+	serverAddCmd.Flags().BoolVar(&addInsecureSkipVerify, "insecure-skip-verify", false, "skip TLS certificate verification")
+	serverAddCmd.Flags().StringArrayVar(&addHeaders, "header", nil, "extra header to send on every request, as Key: Value (repeatable)")
+	serverAddCmd.Flags().IntVar(&addTimeoutSeconds, "timeout", 0, "connect timeout in seconds (default 30)")
+
+	serverPingCmd.Flags().BoolVar(&pingAll, "all", false, "ping every configured server")
+	serverPingCmd.Flags().DurationVar(&pingTimeout, "timeout", 5*time.Second, "per-server request timeout")
+	serverPingCmd.Flags().DurationVar(&pingWatch, "watch", 0, "repeat the ping every interval (e.g. 5s), redrawing each time")
+
+	serverCmd.AddCommand(serverAddCmd, serverListCmd, serverUseCmd, serverRemoveCmd, serverCurrentCmd, serverPingCmd)
+}
+
+// Server management functions
+func addServer(server OllamaServer) {
+	// Validate name
+	if server.Name == "" {
+		fmt.Println("Server name cannot be empty")
+		return
+	}
+
+	// Check address format
+	if !strings.Contains(server.Address, ":") {
+		server.Address += ":11434" // Default port
+	}
+
+	// Check if already exists
+	for _, s := range config.Servers {
+		if s.Name == server.Name {
+			fmt.Printf("Server with name '%s' already exists\n", server.Name)
+			return
+		}
+	}
+
+	if err := probeServer(&server); err != nil {
+		fmt.Printf("Warning: could not reach server '%s' (%s): %v\n", server.Name, server.Address, err)
+		fmt.Println("Adding it anyway; fix connectivity before relying on it.")
+	}
+
+	config.Servers = append(config.Servers, server)
+	if err := saveConfig(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Added server '%s' (%s)\n", server.Name, server.Address)
+}
+
+// probeServer does a best-effort connectivity check against a server's
+// configured address, scheme, and auth settings before it's saved, so
+// typos and bad credentials surface immediately instead of on first use.
+func probeServer(server *OllamaServer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := clientForServer(server).Version(ctx)
+	return err
+}
+
+func listServers() {
+	fmt.Println("Available Ollama servers:")
+	for _, server := range config.Servers {
+		currentMark := " "
+		if server.Name == config.Current {
+			currentMark = "*"
+		}
+		fmt.Printf("%s %s: %s\n", currentMark, server.Name, server.Address)
+	}
+}
+
+func useServer(name string) {
+	// Find server
+	found := false
+	for _, server := range config.Servers {
+		if server.Name == name {
+			config.Current = name
+			if err := saveConfig(); err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Printf("Now using server '%s' (%s)\n", name, server.Address)
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		fmt.Printf("Server with name '%s' not found\n", name)
+	}
+}
+
+func removeServer(name string) {
+	if name == "default" {
+		fmt.Println("Cannot remove the default server")
+		return
+	}
+
+	for i, server := range config.Servers {
+		if server.Name == name {
+			// Remove from slice
+			config.Servers = append(config.Servers[:i], config.Servers[i+1:]...)
+
+			// If removing the current server, switch to default
+			if config.Current == name {
+				config.Current = "default"
+			}
+
+			if err := saveConfig(); err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Printf("Removed server '%s'\n", name)
+			return
+		}
+	}
+
+	fmt.Printf("Server with name '%s' not found\n", name)
+}
+
+func showCurrentServer() {
+	for _, server := range config.Servers {
+		if server.Name == config.Current {
+			fmt.Printf("Current server: %s (%s)\n", server.Name, server.Address)
+			return
+		}
+	}
+	fmt.Println("No current server selected")
+}
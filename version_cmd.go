@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show version information",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("Ollamanager v%s\n", version)
+		server := getCurrentServer()
+		if server != nil {
+			os.Setenv("OLLAMA_HOST", server.Address)
+			c := exec.Command("ollama", "--version")
+			c.Stdout = os.Stdout
+			c.Stderr = os.Stderr
+			c.Run()
+		}
+	},
+}